@@ -0,0 +1,30 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PluginGroupVersion is the group/version the plugin CRDs are registered under.
+var PluginGroupVersion = schema.GroupVersion{Group: "argoproj.io", Version: "v1alpha1"}
+
+// PluginSchemeBuilder collects the plugin CRD types so callers can add them to a runtime.Scheme, e.g.
+// PluginSchemeBuilder.AddToScheme(scheme.Scheme) alongside the rest of the argoproj.io/v1alpha1 types.
+var PluginSchemeBuilder = runtime.NewSchemeBuilder(addPluginKnownTypes)
+
+// AddPluginTypesToScheme adds the plugin CRD types to s.
+var AddPluginTypesToScheme = PluginSchemeBuilder.AddToScheme
+
+func addPluginKnownTypes(s *runtime.Scheme) error {
+	s.AddKnownTypes(PluginGroupVersion,
+		&TrafficRouterPlugin{},
+		&TrafficRouterPluginList{},
+		&MetricProviderPlugin{},
+		&MetricProviderPluginList{},
+		&StepPlugin{},
+		&StepPluginList{},
+	)
+	metav1.AddToGroupVersion(s, PluginGroupVersion)
+	return nil
+}
@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PluginSpec is the spec shared by the TrafficRouterPlugin, MetricProviderPlugin, and StepPlugin CRDs.
+// It mirrors a single entry of the legacy plugin configmap so the two sources can be merged.
+type PluginSpec struct {
+	// Name is the name of the plugin, in the format <namespace>/<name>, e.g. argoproj-labs/sample-plugin
+	Name string `json:"name"`
+	// Alias is an optional short local name that Rollouts/AnalysisTemplates may reference instead of Name.
+	Alias string `json:"alias,omitempty"`
+	// Location is the URL (or OCI reference) the plugin binary should be downloaded from.
+	Location string `json:"location"`
+	// Sha256 is the expected SHA-256 checksum (hex encoded) of the downloaded plugin binary.
+	Sha256 string `json:"sha256,omitempty"`
+	// Digest is the content-addressable identifier of the plugin artifact, e.g. sha256:<hex>.
+	Digest string `json:"digest,omitempty"`
+	// Disabled allows an operator to keep a plugin CR without it being downloaded/used.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+
+// TrafficRouterPlugin is a cluster-scoped declaration of a single traffic router plugin, allowing a
+// platform team to install one without editing the shared plugin configmap.
+type TrafficRouterPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PluginSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TrafficRouterPluginList is a list of TrafficRouterPlugin resources.
+type TrafficRouterPluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficRouterPlugin `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+
+// MetricProviderPlugin is a cluster-scoped declaration of a single metric provider plugin, allowing a
+// platform team to install one without editing the shared plugin configmap.
+type MetricProviderPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PluginSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MetricProviderPluginList is a list of MetricProviderPlugin resources.
+type MetricProviderPluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MetricProviderPlugin `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+
+// StepPlugin is a cluster-scoped declaration of a single step plugin, allowing a platform team to
+// install one without editing the shared plugin configmap.
+type StepPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PluginSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepPluginList is a list of StepPlugin resources.
+type StepPluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StepPlugin `json:"items"`
+}
@@ -23,6 +23,19 @@ type Config struct {
 	configMap *v1.ConfigMap
 	plugins   []types.PluginItem
 	lock      *sync.RWMutex
+	// resolvedDigests holds the sha256 digest of the binary actually installed on disk for a plugin,
+	// keyed by pluginKey(name, type). This can differ from a pending PluginItem.Digest/Sha256 while a
+	// download/verification is in flight.
+	resolvedDigests map[string]string
+	// drainHook, if set, is called when Run detects that an existing plugin's Location changed.
+	drainHook DrainFunc
+	// pluginStates tracks the install lifecycle of every plugin seen so far, keyed by pluginKey(name, type).
+	pluginStates map[string]*PluginState
+}
+
+// pluginKey builds the map key used to track per-plugin runtime state.
+func pluginKey(name string, pluginType types.PluginType) string {
+	return string(pluginType) + "/" + name
 }
 
 var configMemoryCache *Config
@@ -31,17 +44,34 @@ var mutex = &sync.RWMutex{}
 // Regex to match plugin names, this matches github username and repo limits
 var re = regexp.MustCompile(`^([a-zA-Z0-9\-]+)\/{1}([a-zA-Z0-9_\-.]+)$`)
 
+// Regexes used to validate the optional content-addressing fields of a plugin entry.
+var sha256HexRe = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+var digestRe = regexp.MustCompile(`^sha256:[a-fA-F0-9]{64}$`)
+
 // InitializeConfig initializes the in memory config and downloads the plugins to the filesystem. Subsequent calls to this
 // function will update the configmap in memory.
 func InitializeConfig(k8sClientset kubernetes.Interface, configMapName string) (*Config, error) {
+	return InitializeConfigWithCRDs(k8sClientset, configMapName, nil)
+}
+
+// InitializeConfigWithCRDs behaves like InitializeConfig but additionally federates plugins declared via
+// the TrafficRouterPlugin/MetricProviderPlugin/StepPlugin CRDs, merging them into the same plugin list as
+// the legacy configmap. crdSource may be nil, in which case this is equivalent to InitializeConfig.
+func InitializeConfigWithCRDs(k8sClientset kubernetes.Interface, configMapName string, crdSource CRDPluginSource) (*Config, error) {
 	configMapCluster, err := k8sClientset.CoreV1().ConfigMaps(defaults.Namespace()).Get(context.Background(), configMapName, metav1.GetOptions{})
 	if err != nil {
 		if k8errors.IsNotFound(err) {
+			plugins, mergeErr := mergeWithCRDPlugins(nil, crdSource)
+			if mergeErr != nil {
+				return nil, fmt.Errorf("failed to merge CRD plugins while initializing: %w", mergeErr)
+			}
 			configMemoryCache = &Config{
-				lock: &sync.RWMutex{},
+				lock:            &sync.RWMutex{},
+				plugins:         plugins,
+				resolvedDigests: map[string]string{},
 			} // We create an empty config so that we don't try to initialize again
-			// If the configmap is not found, we return
-			return configMemoryCache, nil
+			// If the configmap is not found, we still aggregate any CRD-declared plugins
+			return configMemoryCache, configMemoryCache.ValidateConfig()
 		}
 		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", defaults.Namespace(), configMapName, err)
 	}
@@ -70,11 +100,17 @@ func InitializeConfig(k8sClientset kubernetes.Interface, configMapName string) (
 		stepPlugins[i].Type = types.PluginTypeStep
 	}
 
+	allPlugins, err := mergeWithCRDPlugins(slices.Concat(trafficRouterPlugins, metricProviderPlugins, stepPlugins), crdSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge CRD plugins while initializing: %w", err)
+	}
+
 	mutex.Lock()
 	configMemoryCache = &Config{
-		configMap: configMapCluster,
-		plugins:   slices.Concat(trafficRouterPlugins, metricProviderPlugins, stepPlugins),
-		lock:      &sync.RWMutex{},
+		configMap:       configMapCluster,
+		plugins:         allPlugins,
+		lock:            &sync.RWMutex{},
+		resolvedDigests: map[string]string{},
 	}
 	mutex.Unlock()
 
@@ -112,10 +148,14 @@ func (c *Config) GetAllPlugins() []types.PluginItem {
 	return append([]types.PluginItem{}, c.plugins...)
 }
 
-// GetPlugin returns the plugin item by name and type if it exists
+// GetPlugin returns the plugin item by name and type if it exists. name may be either the canonical
+// <namespace>/<name> form or a configured alias.
 func (c *Config) GetPlugin(name string, pluginType types.PluginType) *types.PluginItem {
 	for _, plugin := range c.GetAllPlugins() {
-		if plugin.Name == name && plugin.Type == pluginType {
+		if plugin.Type != pluginType {
+			continue
+		}
+		if plugin.Name == name || (plugin.Alias != "" && plugin.Alias == name) {
 			return &plugin
 		}
 	}
@@ -123,15 +163,81 @@ func (c *Config) GetPlugin(name string, pluginType types.PluginType) *types.Plug
 }
 
 func (c *Config) ValidateConfig() error {
-	for _, pluginItem := range c.GetAllPlugins() {
+	allPlugins := c.GetAllPlugins()
+
+	canonicalNames := map[types.PluginType]map[string]bool{}
+	for _, pluginItem := range allPlugins {
+		if canonicalNames[pluginItem.Type] == nil {
+			canonicalNames[pluginItem.Type] = map[string]bool{}
+		}
+		canonicalNames[pluginItem.Type][pluginItem.Name] = true
+	}
+
+	aliases := map[types.PluginType]map[string]bool{}
+	for _, pluginItem := range allPlugins {
 		matches := re.FindAllStringSubmatch(pluginItem.Name, -1)
 		if len(matches) != 1 || len(matches[0]) != 3 {
 			return fmt.Errorf("plugin repository (%s) must be in the format of <namespace>/<name>", pluginItem.Name)
 		}
+		if pluginItem.Alias != "" {
+			if canonicalNames[pluginItem.Type][pluginItem.Alias] {
+				return fmt.Errorf("plugin alias (%s) collides with the canonical name of another %s plugin", pluginItem.Alias, pluginItem.Type)
+			}
+			if aliases[pluginItem.Type] == nil {
+				aliases[pluginItem.Type] = map[string]bool{}
+			}
+			if aliases[pluginItem.Type][pluginItem.Alias] {
+				return fmt.Errorf("plugin alias (%s) is used by more than one %s plugin", pluginItem.Alias, pluginItem.Type)
+			}
+			aliases[pluginItem.Type][pluginItem.Alias] = true
+		}
+		if pluginItem.Sha256 != "" {
+			if !sha256HexRe.MatchString(pluginItem.Sha256) {
+				return fmt.Errorf("plugin %s has an invalid sha256 (%s): expected 64 hex characters", pluginItem.Name, pluginItem.Sha256)
+			}
+		}
+		if pluginItem.Digest != "" {
+			if !digestRe.MatchString(pluginItem.Digest) {
+				return fmt.Errorf("plugin %s has an invalid digest (%s): expected the form sha256:<hex>", pluginItem.Name, pluginItem.Digest)
+			}
+			if pluginItem.Location == "" {
+				return fmt.Errorf("plugin %s has a digest but no location: an OCI registry reference (e.g. ghcr.io/org/plugin) is required in location to resolve it by digest", pluginItem.Name)
+			}
+		}
 	}
 	return nil
 }
 
+// GetPluginDigest returns the sha256 digest of the binary currently installed on disk for the given
+// plugin, or "" if nothing has been installed for it yet.
+func (c *Config) GetPluginDigest(name string, pluginType types.PluginType) string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.resolvedDigests[pluginKey(name, pluginType)]
+}
+
+// SetPluginDigest records the sha256 digest of the binary that was just verified and installed on disk
+// for the given plugin. It is called by the downloader once InstallVerified succeeds.
+func (c *Config) SetPluginDigest(name string, pluginType types.PluginType, digest string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.resolvedDigests == nil {
+		c.resolvedDigests = map[string]string{}
+	}
+	c.resolvedDigests[pluginKey(name, pluginType)] = digest
+}
+
+// GetAllPluginDigests returns a copy of the installed-digest map for every plugin tracked so far.
+func (c *Config) GetAllPluginDigests() map[string]string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	out := make(map[string]string, len(c.resolvedDigests))
+	for k, v := range c.resolvedDigests {
+		out[k] = v
+	}
+	return out
+}
+
 // GetPluginDirectoryAndFilename this functions return the directory and file name from a given pluginName such as
 // argoproj-labs/sample-plugin
 func GetPluginDirectoryAndFilename(pluginName string) (directory string, filename string, err error) {
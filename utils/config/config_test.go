@@ -0,0 +1,132 @@
+package config
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+func newTestConfig(plugins ...types.PluginItem) *Config {
+	return &Config{
+		plugins: plugins,
+		lock:    &sync.RWMutex{},
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugins []types.PluginItem
+		wantErr string
+	}{
+		{
+			name: "valid plugin with no optional fields",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/sample-plugin", Type: types.PluginTypeTrafficRouter},
+			},
+		},
+		{
+			name: "invalid repository format",
+			plugins: []types.PluginItem{
+				{Name: "sample-plugin", Type: types.PluginTypeTrafficRouter},
+			},
+			wantErr: "must be in the format of <namespace>/<name>",
+		},
+		{
+			name: "alias collides with another plugin's canonical name",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/other", Type: types.PluginTypeTrafficRouter},
+				{Name: "argoproj-labs/sample-plugin", Alias: "argoproj-labs/other", Type: types.PluginTypeTrafficRouter},
+			},
+			wantErr: "collides with the canonical name",
+		},
+		{
+			name: "alias used by more than one plugin",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/a", Alias: "myrouter", Type: types.PluginTypeTrafficRouter},
+				{Name: "argoproj-labs/b", Alias: "myrouter", Type: types.PluginTypeTrafficRouter},
+			},
+			wantErr: "is used by more than one",
+		},
+		{
+			name: "same alias on different plugin types is fine",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/a", Alias: "myplugin", Type: types.PluginTypeTrafficRouter},
+				{Name: "argoproj-labs/b", Alias: "myplugin", Type: types.PluginTypeMetricProvider},
+			},
+		},
+		{
+			name: "invalid sha256",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/sample-plugin", Sha256: "not-hex", Type: types.PluginTypeTrafficRouter},
+			},
+			wantErr: "invalid sha256",
+		},
+		{
+			name: "valid sha256",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/sample-plugin", Sha256: strings.Repeat("a", 64), Type: types.PluginTypeTrafficRouter},
+			},
+		},
+		{
+			name: "invalid digest format",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/sample-plugin", Digest: "sha256:not-hex", Location: "ghcr.io/org/plugin", Type: types.PluginTypeTrafficRouter},
+			},
+			wantErr: "invalid digest",
+		},
+		{
+			name: "digest without location",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/sample-plugin", Digest: "sha256:" + strings.Repeat("a", 64), Type: types.PluginTypeTrafficRouter},
+			},
+			wantErr: "has a digest but no location",
+		},
+		{
+			name: "valid digest with scheme-less OCI location",
+			plugins: []types.PluginItem{
+				{Name: "argoproj-labs/sample-plugin", Digest: "sha256:" + strings.Repeat("a", 64), Location: "ghcr.io/org/plugin", Type: types.PluginTypeTrafficRouter},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConfig(tt.plugins...)
+			err := c.ValidateConfig()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetPlugin(t *testing.T) {
+	c := newTestConfig(
+		types.PluginItem{Name: "argoproj-labs/sample-plugin", Alias: "myrouter", Type: types.PluginTypeTrafficRouter},
+	)
+
+	if p := c.GetPlugin("argoproj-labs/sample-plugin", types.PluginTypeTrafficRouter); p == nil {
+		t.Fatal("expected to find plugin by canonical name")
+	}
+	if p := c.GetPlugin("myrouter", types.PluginTypeTrafficRouter); p == nil {
+		t.Fatal("expected to find plugin by alias")
+	}
+	if p := c.GetPlugin("myrouter", types.PluginTypeMetricProvider); p != nil {
+		t.Fatal("expected alias lookup to respect plugin type")
+	}
+	if p := c.GetPlugin("argoproj-labs/missing", types.PluginTypeTrafficRouter); p != nil {
+		t.Fatal("expected nil for unknown plugin")
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+// CRDPluginSource lists the cluster-scoped TrafficRouterPlugin/MetricProviderPlugin/StepPlugin CRs so
+// they can be federated with the legacy configmap-based plugin list. utils/plugin/crdsource provides a
+// dynamic.Interface-backed implementation; a nil source disables CRD aggregation entirely so clusters
+// that still only use the configmap are unaffected. Nothing in this tree constructs a non-nil source and
+// passes it to InitializeConfigWithCRDs — there is no controller bootstrap/main.go here to wire that up
+// against a real cluster, so the aggregation path is exercised by mergeWithCRDPlugins directly (and its
+// tests) but not yet reachable end to end.
+type CRDPluginSource interface {
+	ListTrafficRouterPlugins() ([]v1alpha1.TrafficRouterPlugin, error)
+	ListMetricProviderPlugins() ([]v1alpha1.MetricProviderPlugin, error)
+	ListStepPlugins() ([]v1alpha1.StepPlugin, error)
+}
+
+// pluginSpecToItem converts a CRD's PluginSpec into the same types.PluginItem used for configmap
+// entries so the rest of the package (validation, lookup, reconcile) doesn't need to know where a
+// plugin came from.
+func pluginSpecToItem(spec v1alpha1.PluginSpec, pluginType types.PluginType) types.PluginItem {
+	return types.PluginItem{
+		Name:     spec.Name,
+		Alias:    spec.Alias,
+		Location: spec.Location,
+		Sha256:   spec.Sha256,
+		Digest:   spec.Digest,
+		Disabled: spec.Disabled,
+		Type:     pluginType,
+	}
+}
+
+// mergeWithCRDPlugins combines the plugins declared in the legacy configmap with the ones declared via
+// CRDs. A CR whose <namespace>/<name> already exists in the configmap must describe the exact same
+// artifact (Location and Digest); any difference is a conflict and fails the merge, since silently
+// preferring one source over the other would make installed plugins depend on load order.
+func mergeWithCRDPlugins(configMapPlugins []types.PluginItem, source CRDPluginSource) ([]types.PluginItem, error) {
+	if source == nil {
+		return configMapPlugins, nil
+	}
+
+	merged := append([]types.PluginItem{}, configMapPlugins...)
+	byKey := make(map[string]types.PluginItem, len(configMapPlugins))
+	for _, p := range configMapPlugins {
+		byKey[pluginKey(p.Name, p.Type)] = p
+	}
+
+	trafficRouterPlugins, err := source.ListTrafficRouterPlugins()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TrafficRouterPlugin CRs: %w", err)
+	}
+	for _, crd := range trafficRouterPlugins {
+		item := pluginSpecToItem(crd.Spec, types.PluginTypeTrafficRouter)
+		merged, err = addOrConflict(merged, byKey, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	metricProviderPlugins, err := source.ListMetricProviderPlugins()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MetricProviderPlugin CRs: %w", err)
+	}
+	for _, crd := range metricProviderPlugins {
+		item := pluginSpecToItem(crd.Spec, types.PluginTypeMetricProvider)
+		merged, err = addOrConflict(merged, byKey, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stepPlugins, err := source.ListStepPlugins()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list StepPlugin CRs: %w", err)
+	}
+	for _, crd := range stepPlugins {
+		item := pluginSpecToItem(crd.Spec, types.PluginTypeStep)
+		merged, err = addOrConflict(merged, byKey, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+// addOrConflict adds item to merged unless a plugin with the same key is already present, in which
+// case it must have the same Location and Digest or the merge fails.
+func addOrConflict(merged []types.PluginItem, byKey map[string]types.PluginItem, item types.PluginItem) ([]types.PluginItem, error) {
+	key := pluginKey(item.Name, item.Type)
+	existing, ok := byKey[key]
+	if !ok {
+		byKey[key] = item
+		return append(merged, item), nil
+	}
+	if existing.Location != item.Location || existing.Digest != item.Digest {
+		return nil, fmt.Errorf("plugin %s/%s is declared more than once with different url/digest: configmap/CR sources conflict", item.Type, item.Name)
+	}
+	return merged, nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+// fakeCRDPluginSource is a CRDPluginSource backed by in-memory slices, for tests that don't need an
+// actual cluster.
+type fakeCRDPluginSource struct {
+	trafficRouterPlugins  []v1alpha1.TrafficRouterPlugin
+	metricProviderPlugins []v1alpha1.MetricProviderPlugin
+	stepPlugins           []v1alpha1.StepPlugin
+	err                   error
+}
+
+func (f *fakeCRDPluginSource) ListTrafficRouterPlugins() ([]v1alpha1.TrafficRouterPlugin, error) {
+	return f.trafficRouterPlugins, f.err
+}
+
+func (f *fakeCRDPluginSource) ListMetricProviderPlugins() ([]v1alpha1.MetricProviderPlugin, error) {
+	return f.metricProviderPlugins, f.err
+}
+
+func (f *fakeCRDPluginSource) ListStepPlugins() ([]v1alpha1.StepPlugin, error) {
+	return f.stepPlugins, f.err
+}
+
+func TestMergeWithCRDPlugins_NilSource(t *testing.T) {
+	configMapPlugins := []types.PluginItem{{Name: "argoproj-labs/a", Type: types.PluginTypeTrafficRouter}}
+	merged, err := mergeWithCRDPlugins(configMapPlugins, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(merged) != 1 || merged[0].Name != "argoproj-labs/a" {
+		t.Fatalf("expected configmap plugins to pass through unchanged, got: %+v", merged)
+	}
+}
+
+func TestMergeWithCRDPlugins_AddsCRDPluginsOfEachType(t *testing.T) {
+	source := &fakeCRDPluginSource{
+		trafficRouterPlugins: []v1alpha1.TrafficRouterPlugin{
+			{Spec: v1alpha1.PluginSpec{Name: "argoproj-labs/router", Location: "ghcr.io/org/router"}},
+		},
+		metricProviderPlugins: []v1alpha1.MetricProviderPlugin{
+			{Spec: v1alpha1.PluginSpec{Name: "argoproj-labs/metric", Location: "ghcr.io/org/metric"}},
+		},
+		stepPlugins: []v1alpha1.StepPlugin{
+			{Spec: v1alpha1.PluginSpec{Name: "argoproj-labs/step", Location: "ghcr.io/org/step"}},
+		},
+	}
+
+	merged, err := mergeWithCRDPlugins(nil, source)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 merged plugins, got %d: %+v", len(merged), merged)
+	}
+
+	byType := map[types.PluginType]types.PluginItem{}
+	for _, item := range merged {
+		byType[item.Type] = item
+	}
+	if byType[types.PluginTypeTrafficRouter].Name != "argoproj-labs/router" {
+		t.Errorf("expected traffic router CRD plugin in merged result, got: %+v", byType)
+	}
+	if byType[types.PluginTypeMetricProvider].Name != "argoproj-labs/metric" {
+		t.Errorf("expected metric provider CRD plugin in merged result, got: %+v", byType)
+	}
+	if byType[types.PluginTypeStep].Name != "argoproj-labs/step" {
+		t.Errorf("expected step CRD plugin in merged result, got: %+v", byType)
+	}
+}
+
+func TestMergeWithCRDPlugins_IdenticalDuplicateIsNotAConflict(t *testing.T) {
+	configMapPlugins := []types.PluginItem{
+		{Name: "argoproj-labs/a", Location: "ghcr.io/org/a", Digest: "sha256:abc", Type: types.PluginTypeTrafficRouter},
+	}
+	source := &fakeCRDPluginSource{
+		trafficRouterPlugins: []v1alpha1.TrafficRouterPlugin{
+			{Spec: v1alpha1.PluginSpec{Name: "argoproj-labs/a", Location: "ghcr.io/org/a", Digest: "sha256:abc"}},
+		},
+	}
+
+	merged, err := mergeWithCRDPlugins(configMapPlugins, source)
+	if err != nil {
+		t.Fatalf("expected no error for an identical duplicate, got: %v", err)
+	}
+	if len(merged) != 1 {
+		t.Fatalf("expected the duplicate to be deduplicated, got: %+v", merged)
+	}
+}
+
+func TestMergeWithCRDPlugins_ConflictingDuplicateFails(t *testing.T) {
+	configMapPlugins := []types.PluginItem{
+		{Name: "argoproj-labs/a", Location: "ghcr.io/org/a", Type: types.PluginTypeTrafficRouter},
+	}
+	source := &fakeCRDPluginSource{
+		trafficRouterPlugins: []v1alpha1.TrafficRouterPlugin{
+			{Spec: v1alpha1.PluginSpec{Name: "argoproj-labs/a", Location: "ghcr.io/org/a-different-fork"}},
+		},
+	}
+
+	_, err := mergeWithCRDPlugins(configMapPlugins, source)
+	if err == nil {
+		t.Fatal("expected a conflict error, got nil")
+	}
+	if !strings.Contains(err.Error(), "more than once with different url/digest") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMergeWithCRDPlugins_PropagatesListError(t *testing.T) {
+	source := &fakeCRDPluginSource{err: fmt.Errorf("boom")}
+	_, err := mergeWithCRDPlugins(nil, source)
+	if err == nil {
+		t.Fatal("expected the list error to propagate, got nil")
+	}
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-rollouts/utils/defaults"
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+// pluginReconcileTotal counts add/remove/fail events produced while reconciling the plugin configmap,
+// labeled by the outcome and the plugin that triggered it.
+var pluginReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "rollouts_plugin_reconcile_total",
+	Help: "Count of plugin add/remove/fail events observed while reconciling the plugin configmap",
+}, []string{"name", "type", "result"})
+
+func init() {
+	prometheus.MustRegister(pluginReconcileTotal)
+}
+
+// DrainFunc is invoked when a plugin's location (and therefore its running binary) changes so that
+// callers holding long-lived RPC clients for it, such as step plugins, can gracefully re-dial.
+type DrainFunc func(old, updated types.PluginItem)
+
+// Installer installs and removes plugin binaries on disk. It is the hook point reconcile uses to
+// download newly added plugins and clean up removed ones; it is satisfied by the download package.
+type Installer interface {
+	Install(item types.PluginItem) error
+	Remove(item types.PluginItem) error
+}
+
+// RegisterDrainHook registers a callback invoked for every plugin whose Location changes during a
+// hot-reload. It replaces any previously registered hook.
+func (c *Config) RegisterDrainHook(fn DrainFunc) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.drainHook = fn
+}
+
+// Run starts a SharedInformer watching the argo-rollouts plugin configmap and keeps the in-memory
+// config in sync with it until ctx is cancelled. It is safe to call GetPlugin/GetAllPlugins on c from
+// other goroutines while Run is active, updates are applied atomically under c.lock.
+func (c *Config) Run(ctx context.Context, k8sClientset kubernetes.Interface, configMapName string, installer Installer, recorder record.EventRecorder) error {
+	factory := cache.NewSharedInformer(
+		cache.NewListWatchFromClient(
+			k8sClientset.CoreV1().RESTClient(),
+			"configmaps",
+			defaults.Namespace(),
+			fields.OneTermEqualSelector("metadata.name", configMapName),
+		),
+		&v1.ConfigMap{},
+		0,
+	)
+
+	_, err := factory.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			c.reconcileConfigMap(obj.(*v1.ConfigMap), installer, recorder)
+		},
+		UpdateFunc: func(_, newObj any) {
+			c.reconcileConfigMap(newObj.(*v1.ConfigMap), installer, recorder)
+		},
+		DeleteFunc: func(_ any) {
+			c.reconcileConfigMap(nil, installer, recorder)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register plugin configmap event handler: %w", err)
+	}
+
+	go factory.Run(ctx.Done())
+	return nil
+}
+
+// reconcileConfigMap re-parses configMap (nil meaning it was deleted), validates it, diffs it against
+// the currently cached plugin set, and installs/removes/drains plugins accordingly before swapping the
+// cache.
+func (c *Config) reconcileConfigMap(configMap *v1.ConfigMap, installer Installer, recorder record.EventRecorder) {
+	oldPlugins := c.GetAllPlugins()
+
+	var newPlugins []types.PluginItem
+	var newConfigMap *v1.ConfigMap
+	if configMap != nil {
+		parsed, err := parsePluginsFromConfigMap(configMap)
+		if err != nil {
+			c.recordReconcileResult(recorder, configMap, "", "", "fail", fmt.Sprintf("failed to parse plugin configmap: %v", err))
+			return
+		}
+		newPlugins = parsed
+		newConfigMap = configMap
+	}
+
+	candidate := &Config{
+		configMap:       newConfigMap,
+		plugins:         newPlugins,
+		lock:            c.lock,
+		resolvedDigests: c.GetAllPluginDigests(),
+	}
+	if err := candidate.ValidateConfig(); err != nil {
+		c.recordReconcileResult(recorder, configMap, "", "", "fail", fmt.Sprintf("plugin configmap failed validation, keeping previous config: %v", err))
+		return
+	}
+
+	added, removed, changed := diffPlugins(oldPlugins, newPlugins)
+
+	for _, item := range added {
+		if item.Disabled {
+			c.SetPluginPhase(item, PluginPhaseDisabled, nil)
+			continue
+		}
+		c.SetPluginPhase(item, PluginPhasePending, nil)
+		if installer != nil {
+			c.SetPluginPhase(item, PluginPhaseDownloading, nil)
+			if err := installer.Install(item); err != nil {
+				c.SetPluginPhase(item, PluginPhaseFailed, err)
+				c.recordReconcileResult(recorder, configMap, item.Name, string(item.Type), "fail", fmt.Sprintf("failed to install plugin %s: %v", item.Name, err))
+				continue
+			}
+		}
+		c.SetPluginPhase(item, PluginPhaseReady, nil)
+		c.recordReconcileResult(recorder, configMap, item.Name, string(item.Type), "add", fmt.Sprintf("installed plugin %s", item.Name))
+	}
+	for _, item := range removed {
+		if installer != nil {
+			if err := installer.Remove(item); err != nil {
+				c.SetPluginPhase(item, PluginPhaseFailed, err)
+				c.recordReconcileResult(recorder, configMap, item.Name, string(item.Type), "fail", fmt.Sprintf("failed to remove plugin %s: %v", item.Name, err))
+				continue
+			}
+		}
+		c.forgetPluginStatus(item)
+		c.recordReconcileResult(recorder, configMap, item.Name, string(item.Type), "remove", fmt.Sprintf("removed plugin %s", item.Name))
+	}
+	for _, pair := range changed {
+		oldItem, newItem := pair[0], pair[1]
+		if newItem.Disabled {
+			c.SetPluginPhase(newItem, PluginPhaseDisabled, nil)
+			continue
+		}
+		c.SetPluginPhase(newItem, PluginPhasePending, nil)
+		if installer != nil {
+			c.SetPluginPhase(newItem, PluginPhaseDownloading, nil)
+			if err := installer.Install(newItem); err != nil {
+				c.SetPluginPhase(newItem, PluginPhaseFailed, err)
+				c.recordReconcileResult(recorder, configMap, newItem.Name, string(newItem.Type), "fail", fmt.Sprintf("failed to re-install changed plugin %s: %v", newItem.Name, err))
+				continue
+			}
+		}
+		c.SetPluginPhase(newItem, PluginPhaseReady, nil)
+		c.recordReconcileResult(recorder, configMap, newItem.Name, string(newItem.Type), "add", fmt.Sprintf("re-installed changed plugin %s", newItem.Name))
+		if c.drainHook != nil {
+			c.drainHook(oldItem, newItem)
+		}
+	}
+
+	c.lock.Lock()
+	c.configMap = newConfigMap
+	c.plugins = newPlugins
+	c.lock.Unlock()
+}
+
+// diffPlugins compares the previous and current plugin sets, keyed by name+type, and returns plugins
+// that were added, removed, and those whose Location, Digest, or Sha256 changed in place (i.e. the
+// same plugin now points at a different artifact, including a digest rotation).
+func diffPlugins(oldPlugins, newPlugins []types.PluginItem) (added, removed []types.PluginItem, changed [][2]types.PluginItem) {
+	oldByKey := make(map[string]types.PluginItem, len(oldPlugins))
+	for _, p := range oldPlugins {
+		oldByKey[pluginKey(p.Name, p.Type)] = p
+	}
+	newByKey := make(map[string]types.PluginItem, len(newPlugins))
+	for _, p := range newPlugins {
+		newByKey[pluginKey(p.Name, p.Type)] = p
+	}
+
+	for key, newItem := range newByKey {
+		oldItem, ok := oldByKey[key]
+		if !ok {
+			added = append(added, newItem)
+			continue
+		}
+		if oldItem.Location != newItem.Location || oldItem.Digest != newItem.Digest || oldItem.Sha256 != newItem.Sha256 {
+			changed = append(changed, [2]types.PluginItem{oldItem, newItem})
+		}
+	}
+	for key, oldItem := range oldByKey {
+		if _, ok := newByKey[key]; !ok {
+			removed = append(removed, oldItem)
+		}
+	}
+	return added, removed, changed
+}
+
+// parsePluginsFromConfigMap extracts and tags the three plugin sections the same way InitializeConfig
+// does for the initial load.
+func parsePluginsFromConfigMap(configMap *v1.ConfigMap) ([]types.PluginItem, error) {
+	sections := []struct {
+		key        string
+		pluginType types.PluginType
+	}{
+		{"trafficRouterPlugins", types.PluginTypeTrafficRouter},
+		{"metricProviderPlugins", types.PluginTypeMetricProvider},
+		{"stepPlugins", types.PluginTypeStep},
+	}
+
+	var all []types.PluginItem
+	for _, section := range sections {
+		var items []types.PluginItem
+		if err := yaml.Unmarshal([]byte(configMap.Data[section.key]), &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s while reconciling: %w", section.key, err)
+		}
+		for i := range items {
+			items[i].Type = section.pluginType
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+func (c *Config) recordReconcileResult(recorder record.EventRecorder, configMap *v1.ConfigMap, name, pluginType, result, message string) {
+	pluginReconcileTotal.WithLabelValues(name, pluginType, result).Inc()
+	if recorder != nil && configMap != nil {
+		eventType := v1.EventTypeNormal
+		if result == "fail" {
+			eventType = v1.EventTypeWarning
+		}
+		recorder.Event(configMap, eventType, "PluginReconcile", message)
+	}
+}
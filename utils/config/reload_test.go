@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+func TestDiffPlugins(t *testing.T) {
+	routerA := types.PluginItem{Name: "argoproj-labs/a", Type: types.PluginTypeTrafficRouter, Location: "ghcr.io/org/a", Digest: "sha256:" + repeat("a")}
+	routerAChangedDigest := routerA
+	routerAChangedDigest.Digest = "sha256:" + repeat("b")
+	routerAChangedSha := routerA
+	routerAChangedSha.Sha256 = "somesha"
+	routerB := types.PluginItem{Name: "argoproj-labs/b", Type: types.PluginTypeTrafficRouter, Location: "ghcr.io/org/b"}
+
+	tests := []struct {
+		name        string
+		old, new    []types.PluginItem
+		wantAdded   int
+		wantRemoved int
+		wantChanged int
+	}{
+		{name: "identical", old: []types.PluginItem{routerA}, new: []types.PluginItem{routerA}},
+		{name: "added", old: nil, new: []types.PluginItem{routerA}, wantAdded: 1},
+		{name: "removed", old: []types.PluginItem{routerA}, new: nil, wantRemoved: 1},
+		{name: "digest changed", old: []types.PluginItem{routerA}, new: []types.PluginItem{routerAChangedDigest}, wantChanged: 1},
+		{name: "sha256 changed", old: []types.PluginItem{routerA}, new: []types.PluginItem{routerAChangedSha}, wantChanged: 1},
+		{name: "add and remove distinct plugins", old: []types.PluginItem{routerA}, new: []types.PluginItem{routerB}, wantAdded: 1, wantRemoved: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed, changed := diffPlugins(tt.old, tt.new)
+			if len(added) != tt.wantAdded {
+				t.Errorf("added: got %d, want %d (%+v)", len(added), tt.wantAdded, added)
+			}
+			if len(removed) != tt.wantRemoved {
+				t.Errorf("removed: got %d, want %d (%+v)", len(removed), tt.wantRemoved, removed)
+			}
+			if len(changed) != tt.wantChanged {
+				t.Errorf("changed: got %d, want %d (%+v)", len(changed), tt.wantChanged, changed)
+			}
+		})
+	}
+}
+
+func repeat(s string) string {
+	out := ""
+	for i := 0; i < 64; i++ {
+		out += s
+	}
+	return out
+}
+
+// fakeInstaller records Install/Remove calls so tests can assert reconcileConfigMap drives them.
+type fakeInstaller struct {
+	installed  []types.PluginItem
+	removed    []types.PluginItem
+	installErr error
+}
+
+func (f *fakeInstaller) Install(item types.PluginItem) error {
+	if f.installErr != nil {
+		return f.installErr
+	}
+	f.installed = append(f.installed, item)
+	return nil
+}
+
+func (f *fakeInstaller) Remove(item types.PluginItem) error {
+	f.removed = append(f.removed, item)
+	return nil
+}
+
+func configMapWithTrafficRouterPlugins(t *testing.T, items []types.PluginItem) *v1.ConfigMap {
+	t.Helper()
+	var sb string
+	for _, item := range items {
+		sb += fmt.Sprintf("- name: %s\n  location: %s\n", item.Name, item.Location)
+		if item.Digest != "" {
+			sb += fmt.Sprintf("  digest: %s\n", item.Digest)
+		}
+	}
+	return &v1.ConfigMap{Data: map[string]string{"trafficRouterPlugins": sb}}
+}
+
+func TestReconcileConfigMap_ChangedPluginIsReinstalledBeforeDrain(t *testing.T) {
+	oldItem := types.PluginItem{Name: "argoproj-labs/a", Type: types.PluginTypeTrafficRouter, Location: "ghcr.io/org/a", Digest: "sha256:" + repeat("1")}
+	newItem := types.PluginItem{Name: "argoproj-labs/a", Type: types.PluginTypeTrafficRouter, Location: "ghcr.io/org/a", Digest: "sha256:" + repeat("2")}
+
+	c := newTestConfig(oldItem)
+	c.resolvedDigests = map[string]string{}
+
+	var drainedOld, drainedNew types.PluginItem
+	var installedBeforeDrain bool
+	installer := &fakeInstaller{}
+	c.RegisterDrainHook(func(old, updated types.PluginItem) {
+		drainedOld, drainedNew = old, updated
+		installedBeforeDrain = len(installer.installed) == 1
+	})
+
+	configMap := configMapWithTrafficRouterPlugins(t, []types.PluginItem{newItem})
+	c.reconcileConfigMap(configMap, installer, &record.FakeRecorder{})
+
+	if len(installer.installed) != 1 {
+		t.Fatalf("expected the changed plugin to be (re)installed, got: %+v", installer.installed)
+	}
+	if installer.installed[0].Digest != newItem.Digest {
+		t.Fatalf("expected installer to receive the new digest, got: %+v", installer.installed[0])
+	}
+	if !installedBeforeDrain {
+		t.Fatal("expected Install to run before the drain hook fires")
+	}
+	if drainedOld.Digest != oldItem.Digest || drainedNew.Digest != newItem.Digest {
+		t.Fatalf("expected drain hook to receive old/new items, got old=%+v new=%+v", drainedOld, drainedNew)
+	}
+
+	status := c.GetPluginStatus(newItem.Name, newItem.Type)
+	if status == nil || status.Phase != PluginPhaseReady {
+		t.Fatalf("expected the reinstalled plugin to end up Ready, got: %+v", status)
+	}
+}
+
+func TestReconcileConfigMap_ChangedPluginInstallFailureSkipsDrain(t *testing.T) {
+	oldItem := types.PluginItem{Name: "argoproj-labs/a", Type: types.PluginTypeTrafficRouter, Location: "ghcr.io/org/a"}
+	newItem := types.PluginItem{Name: "argoproj-labs/a", Type: types.PluginTypeTrafficRouter, Location: "ghcr.io/org/a-new"}
+
+	c := newTestConfig(oldItem)
+	c.resolvedDigests = map[string]string{}
+
+	drainCalled := false
+	installer := &fakeInstaller{installErr: fmt.Errorf("download failed")}
+	c.RegisterDrainHook(func(old, updated types.PluginItem) { drainCalled = true })
+
+	configMap := configMapWithTrafficRouterPlugins(t, []types.PluginItem{newItem})
+	c.reconcileConfigMap(configMap, installer, &record.FakeRecorder{})
+
+	if drainCalled {
+		t.Fatal("expected drain hook not to fire when the re-install fails")
+	}
+	status := c.GetPluginStatus(newItem.Name, newItem.Type)
+	if status == nil || status.Phase != PluginPhaseFailed {
+		t.Fatalf("expected the plugin to be marked Failed, got: %+v", status)
+	}
+}
@@ -0,0 +1,89 @@
+// Package crdsource provides a config.CRDPluginSource implementation backed by a dynamic.Interface
+// rather than a generated clientset/lister. This tree has no codegen tooling wired up for the plugin
+// CRDs (no generated clientset, lister, or informer), so listing the three kinds through the dynamic
+// client and converting with runtime.DefaultUnstructuredConverter is the only option that doesn't
+// require hand-writing a full generated-client tree by hand.
+package crdsource
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+)
+
+var (
+	trafficRouterPluginResource  = v1alpha1.PluginGroupVersion.WithResource("trafficrouterplugins")
+	metricProviderPluginResource = v1alpha1.PluginGroupVersion.WithResource("metricproviderplugins")
+	stepPluginResource           = v1alpha1.PluginGroupVersion.WithResource("stepplugins")
+)
+
+// DynamicCRDPluginSource implements config.CRDPluginSource by listing the plugin CRDs through a
+// dynamic.Interface and converting each item to its typed form. It's the one piece of wiring this tree
+// is missing to actually run the CRD-aggregation path: there is no controller bootstrap/main.go in this
+// snapshot to pass the result to config.InitializeConfigWithCRDs, so constructing one here is necessary
+// but not sufficient to make the feature run end to end against a real cluster.
+type DynamicCRDPluginSource struct {
+	client dynamic.Interface
+}
+
+// NewDynamicCRDPluginSource returns a DynamicCRDPluginSource that lists the plugin CRDs via client.
+func NewDynamicCRDPluginSource(client dynamic.Interface) *DynamicCRDPluginSource {
+	return &DynamicCRDPluginSource{client: client}
+}
+
+// ListTrafficRouterPlugins implements config.CRDPluginSource.
+func (s *DynamicCRDPluginSource) ListTrafficRouterPlugins() ([]v1alpha1.TrafficRouterPlugin, error) {
+	items := &v1alpha1.TrafficRouterPluginList{}
+	if err := s.list(trafficRouterPluginResource, items); err != nil {
+		return nil, err
+	}
+	return items.Items, nil
+}
+
+// ListMetricProviderPlugins implements config.CRDPluginSource.
+func (s *DynamicCRDPluginSource) ListMetricProviderPlugins() ([]v1alpha1.MetricProviderPlugin, error) {
+	items := &v1alpha1.MetricProviderPluginList{}
+	if err := s.list(metricProviderPluginResource, items); err != nil {
+		return nil, err
+	}
+	return items.Items, nil
+}
+
+// ListStepPlugins implements config.CRDPluginSource.
+func (s *DynamicCRDPluginSource) ListStepPlugins() ([]v1alpha1.StepPlugin, error) {
+	items := &v1alpha1.StepPluginList{}
+	if err := s.list(stepPluginResource, items); err != nil {
+		return nil, err
+	}
+	return items.Items, nil
+}
+
+// list fetches gvr cluster-wide and converts the result into out.
+func (s *DynamicCRDPluginSource) list(gvr schema.GroupVersionResource, out any) error {
+	unstructuredList, err := s.client.Resource(gvr).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredAsMap(unstructuredList), out); err != nil {
+		return fmt.Errorf("failed to convert %s list: %w", gvr.Resource, err)
+	}
+	return nil
+}
+
+// unstructuredAsMap returns list's underlying map, the form runtime.DefaultUnstructuredConverter expects.
+func unstructuredAsMap(list *unstructured.UnstructuredList) map[string]any {
+	out := list.UnstructuredContent()
+	items := make([]any, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, item.UnstructuredContent())
+	}
+	out["items"] = items
+	return out
+}
@@ -0,0 +1,55 @@
+// Package download fetches plugin binaries and verifies them before installing them to disk.
+package download
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/argoproj/argo-rollouts/utils/plugin/verify"
+)
+
+// InstallVerified reads src, verifies it against expectedDigest using v, and only then atomically
+// installs it at destPath. If verification fails, destPath is left untouched so a previously installed
+// and verified binary keeps running.
+func InstallVerified(v verify.Verifier, src io.Reader, destPath string, expectedDigest string) error {
+	dir := filepath.Dir(destPath)
+	tmpFile, err := os.CreateTemp(dir, ".tmp-"+filepath.Base(destPath)+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for plugin download in %s: %w", dir, err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := io.Copy(tmpFile, src); err != nil {
+		return fmt.Errorf("failed to write plugin download to %s: %w", tmpPath, err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file %s: %w", tmpPath, err)
+	}
+
+	if expectedDigest != "" {
+		f, err := os.Open(tmpPath)
+		if err != nil {
+			return fmt.Errorf("failed to reopen downloaded plugin for verification: %w", err)
+		}
+		verifyErr := v.Verify(f, expectedDigest)
+		f.Close()
+		if verifyErr != nil {
+			return fmt.Errorf("plugin download failed verification, keeping previous binary at %s in place: %w", destPath, verifyErr)
+		}
+	}
+
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return fmt.Errorf("failed to set executable permission on downloaded plugin: %w", err)
+	}
+
+	// Rename is atomic on POSIX filesystems when src and dest share a directory, so readers of destPath
+	// never observe a partially written or unverified binary.
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("failed to install verified plugin to %s: %w", destPath, err)
+	}
+	return nil
+}
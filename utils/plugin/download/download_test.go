@@ -0,0 +1,91 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/argo-rollouts/utils/plugin/verify"
+)
+
+func TestInstallVerified_MatchingDigestInstalls(t *testing.T) {
+	dir := t.TempDir()
+	destPath := filepath.Join(dir, "plugin")
+	const content = "plugin-binary"
+
+	v := verify.NewSha256Verifier()
+	digest := sha256Hex(t, content)
+
+	if err := InstallVerified(v, strings.NewReader(content), destPath, digest); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("expected the binary to be installed, got: %v", err)
+	}
+	if string(got) != content {
+		t.Fatalf("expected installed content %q, got %q", content, got)
+	}
+
+	info, err := os.Stat(destPath)
+	if err != nil {
+		t.Fatalf("failed to stat installed binary: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Fatalf("expected the installed binary to be executable, got mode %v", info.Mode())
+	}
+}
+
+func TestInstallVerified_MismatchedDigestLeavesExistingBinaryInPlace(t *testing.T) {
+	dir := t.TempDir()
+	destPath := filepath.Join(dir, "plugin")
+	const existingContent = "previously-installed-binary"
+	if err := os.WriteFile(destPath, []byte(existingContent), 0755); err != nil {
+		t.Fatalf("failed to seed existing binary: %v", err)
+	}
+
+	v := verify.NewSha256Verifier()
+	wrongDigest := strings.Repeat("a", 64)
+
+	err := InstallVerified(v, strings.NewReader("new-but-corrupt-binary"), destPath, wrongDigest)
+	if err == nil {
+		t.Fatal("expected a verification error, got nil")
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("failed to read destPath after failed install: %v", err)
+	}
+	if string(got) != existingContent {
+		t.Fatalf("expected the previously installed binary to be left untouched, got %q", got)
+	}
+}
+
+func TestInstallVerified_NoDigestSkipsVerification(t *testing.T) {
+	dir := t.TempDir()
+	destPath := filepath.Join(dir, "plugin")
+	const content = "unverified-binary"
+
+	v := verify.NewSha256Verifier()
+	if err := InstallVerified(v, strings.NewReader(content), destPath, ""); err != nil {
+		t.Fatalf("expected no error when no digest is provided, got: %v", err)
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("expected the binary to be installed, got: %v", err)
+	}
+	if string(got) != content {
+		t.Fatalf("expected installed content %q, got %q", content, got)
+	}
+}
+
+func sha256Hex(t *testing.T, content string) string {
+	t.Helper()
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,232 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-rollouts/utils/plugin/oci"
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+// defaultDownloadTimeout bounds a single download attempt when a plugin entry doesn't set its own.
+const defaultDownloadTimeout = 2 * time.Minute
+
+// defaultMaxRetries is the number of additional attempts made after a failed download before giving up.
+const defaultMaxRetries = 4
+
+// permanentError marks a fetchOnce failure that retrying cannot fix, e.g. a malformed location or an
+// unsupported scheme, so Fetch can abort the backoff loop immediately instead of retrying it
+// defaultMaxRetries times for something that will never succeed.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// permanent wraps err as a permanentError, or returns nil if err is nil.
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Downloader fetches the binary described by a plugin entry and returns a reader over its contents
+// along with the digest of what was fetched (when the transport can determine one cheaply, e.g. an OCI
+// digest or a ConfigMap/Secret's own checksum; "" otherwise, in which case only item.Sha256/Digest are
+// authoritative).
+type Downloader interface {
+	Fetch(ctx context.Context, item types.PluginItem) (io.ReadCloser, string, error)
+}
+
+// MultiDownloader dispatches to a scheme-specific Downloader based on the plugin's Location prefix and
+// retries transient failures with exponential backoff.
+type MultiDownloader struct {
+	httpClient *http.Client
+	k8sClient  kubernetes.Interface
+}
+
+// NewMultiDownloader returns a MultiDownloader supporting http(s)://, oci://, file://, configmap://, and
+// secret:// locations. k8sClient is required for the configmap:// and secret:// schemes; it may be nil
+// if those schemes aren't used.
+func NewMultiDownloader(httpClient *http.Client, k8sClient kubernetes.Interface) *MultiDownloader {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &MultiDownloader{httpClient: httpClient, k8sClient: k8sClient}
+}
+
+// Fetch downloads item.Location, retrying transient errors with exponential backoff, and bounding the
+// whole attempt sequence and the read of the returned body by item.DownloadTimeout (or
+// defaultDownloadTimeout if unset). The timeout applies to the http(s)://, configmap:// and secret://
+// transports; OCI pulls and file:// reads do not observe it. Closing the returned reader releases it.
+func (d *MultiDownloader) Fetch(ctx context.Context, item types.PluginItem) (io.ReadCloser, string, error) {
+	timeout := item.DownloadTimeout.Duration
+	if timeout <= 0 {
+		timeout = defaultDownloadTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	var (
+		rc      io.ReadCloser
+		digest  string
+		lastErr error
+	)
+	backoff := wait.Backoff{
+		Duration: 500 * time.Millisecond,
+		Factor:   2.0,
+		Steps:    defaultMaxRetries + 1,
+	}
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		rc, digest, lastErr = d.fetchOnce(ctx, item)
+		if lastErr == nil {
+			return true, nil
+		}
+		var perm *permanentError
+		if errors.As(lastErr, &perm) {
+			// Stop immediately: a malformed location or unsupported scheme will never succeed no matter
+			// how many times we retry it.
+			return false, lastErr
+		}
+		// Treat every other transport-level error as transient and retry until the backoff is exhausted;
+		// the surrounding ctx timeout is what ultimately bounds a permanently broken source.
+		return false, nil
+	})
+	if err != nil {
+		// lastErr carries the actual fetch failure; err is only the generic wait.ErrWaitTimeout sentinel
+		// once the backoff is exhausted without lastErr ever being nil.
+		if lastErr != nil {
+			err = lastErr
+		}
+		cancel()
+		return nil, "", fmt.Errorf("failed to download plugin %s from %s after retries: %w", item.Name, item.Location, err)
+	}
+	// The http(s) body is tied to ctx, so ctx stays alive until the caller closes the reader.
+	return &cancelOnClose{ReadCloser: rc, cancel: cancel}, digest, nil
+}
+
+// cancelOnClose releases Fetch's timeout context once the caller has finished with the body.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+func (d *MultiDownloader) fetchOnce(ctx context.Context, item types.PluginItem) (io.ReadCloser, string, error) {
+	switch {
+	case strings.HasPrefix(item.Location, "oci://"):
+		return d.fetchOCI(strings.TrimPrefix(item.Location, "oci://"), item.Digest)
+	case strings.HasPrefix(item.Location, "http://"), strings.HasPrefix(item.Location, "https://"):
+		return d.fetchHTTP(ctx, item.Location)
+	case strings.HasPrefix(item.Location, "file://"):
+		return fetchFile(strings.TrimPrefix(item.Location, "file://"))
+	case strings.HasPrefix(item.Location, "configmap://"):
+		return d.fetchConfigMapOrSecret(ctx, strings.TrimPrefix(item.Location, "configmap://"), false)
+	case strings.HasPrefix(item.Location, "secret://"):
+		return d.fetchConfigMapOrSecret(ctx, strings.TrimPrefix(item.Location, "secret://"), true)
+	case item.Digest != "" && !strings.Contains(item.Location, "://"):
+		// config.ValidateConfig documents this bare "<registry>/<repository>" + digest form (e.g.
+		// "ghcr.io/org/plugin") as the way to pin an OCI artifact, mirroring Docker's own convention of
+		// not requiring a scheme on an image reference.
+		return d.fetchOCI(item.Location, item.Digest)
+	default:
+		return nil, "", permanent(fmt.Errorf("unsupported plugin location scheme in %q", item.Location))
+	}
+}
+
+// fetchHTTP downloads over HTTP(S). The http.Client passed to NewMultiDownloader is expected to be
+// configured with the desired proxy behavior, http.ProxyFromEnvironment honors HTTPS_PROXY/NO_PROXY out
+// of the box when the client's Transport is (or embeds) http.DefaultTransport.
+func (d *MultiDownloader) fetchHTTP(ctx context.Context, url string) (io.ReadCloser, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build request for %s: %w", url, err)
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, "", fmt.Errorf("failed to download %s: unexpected status %s", url, resp.Status)
+	}
+	return resp.Body, "", nil
+}
+
+// fetchOCI resolves location@digest's manifest and returns its single layer. The digest returned is the
+// layer's own digest (verified by the puller against the content-addressed manifest), not the pinned
+// manifest digest itself, since that's what the downloaded bytes actually hash to.
+func (d *MultiDownloader) fetchOCI(location, digest string) (io.ReadCloser, string, error) {
+	refStr := location
+	if digest != "" && !strings.Contains(location, "@sha256:") {
+		refStr = location + "@" + digest
+	}
+	ref, err := oci.ParseRef(refStr)
+	if err != nil {
+		return nil, "", permanent(err)
+	}
+	return oci.NewPuller(d.httpClient).PullBlob(ref)
+}
+
+// fetchFile reads a plugin binary staged on the controller's local filesystem, e.g. by an init
+// container.
+func fetchFile(path string) (io.ReadCloser, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open plugin file %s: %w", path, err)
+	}
+	return f, "", nil
+}
+
+// fetchConfigMapOrSecret reads a plugin binary baked into a ConfigMap or Secret, for disconnected
+// clusters that cannot reach an external download source. location is <namespace>/<name>/<key>.
+func (d *MultiDownloader) fetchConfigMapOrSecret(ctx context.Context, location string, isSecret bool) (io.ReadCloser, string, error) {
+	if d.k8sClient == nil {
+		return nil, "", permanent(fmt.Errorf("a kubernetes client is required to resolve %q", location))
+	}
+	parts := strings.SplitN(location, "/", 3)
+	if len(parts) != 3 {
+		return nil, "", permanent(fmt.Errorf("location %q must be in the form <namespace>/<name>/<key>", location))
+	}
+	namespace, name, key := parts[0], parts[1], parts[2]
+
+	if isSecret {
+		secret, err := d.k8sClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+		}
+		data, ok := secret.Data[key]
+		if !ok {
+			return nil, "", fmt.Errorf("secret %s/%s has no key %q", namespace, name, key)
+		}
+		return io.NopCloser(bytes.NewReader(data)), "", nil
+	}
+
+	configMap, err := d.k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get configmap %s/%s: %w", namespace, name, err)
+	}
+	if data, ok := configMap.BinaryData[key]; ok {
+		return io.NopCloser(bytes.NewReader(data)), "", nil
+	}
+	if data, ok := configMap.Data[key]; ok {
+		return io.NopCloser(bytes.NewReader([]byte(data))), "", nil
+	}
+	return nil, "", fmt.Errorf("configmap %s/%s has no key %q", namespace, name, key)
+}
@@ -0,0 +1,63 @@
+package download
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+)
+
+func TestFetch_UnsupportedSchemeFailsFastWithRealError(t *testing.T) {
+	d := NewMultiDownloader(nil, nil)
+	item := types.PluginItem{Name: "argoproj-labs/a", Location: "ftp://example.com/plugin"}
+
+	start := time.Now()
+	_, _, err := d.Fetch(context.Background(), item)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error for an unsupported scheme, got nil")
+	}
+	if !strings.Contains(err.Error(), "unsupported plugin location scheme") {
+		t.Fatalf("expected the real fetch error to be surfaced, got: %v", err)
+	}
+	// The first backoff step is 500ms; failing fast on a permanent error should not pay for any retries.
+	if elapsed >= 500*time.Millisecond {
+		t.Fatalf("expected a permanent error to abort immediately without retrying, took %s", elapsed)
+	}
+}
+
+func TestFetch_MalformedOCIRefFailsFastWithRealError(t *testing.T) {
+	d := NewMultiDownloader(nil, nil)
+	item := types.PluginItem{Name: "argoproj-labs/a", Location: "oci://not-a-valid-ref", Digest: "sha256:" + strings.Repeat("a", 64)}
+
+	_, _, err := d.Fetch(context.Background(), item)
+	if err == nil {
+		t.Fatal("expected an error for a malformed OCI ref, got nil")
+	}
+	if !strings.Contains(err.Error(), "must be in the form") {
+		t.Fatalf("expected the oci.ParseRef error to be surfaced, got: %v", err)
+	}
+}
+
+func TestFetch_FileLocation(t *testing.T) {
+	f := t.TempDir() + "/plugin-binary"
+	if err := os.WriteFile(f, []byte("binary-contents"), 0644); err != nil {
+		t.Fatalf("failed to set up test file: %v", err)
+	}
+
+	d := NewMultiDownloader(nil, nil)
+	item := types.PluginItem{Name: "argoproj-labs/a", Location: "file://" + f}
+
+	rc, digest, err := d.Fetch(context.Background(), item)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer rc.Close()
+	if digest != "" {
+		t.Fatalf("expected fetchFile to report no digest of its own, got %q", digest)
+	}
+}
@@ -0,0 +1,95 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/argoproj/argo-rollouts/utils/config"
+	"github.com/argoproj/argo-rollouts/utils/plugin/types"
+	"github.com/argoproj/argo-rollouts/utils/plugin/verify"
+)
+
+// PluginInstaller downloads, verifies, and installs plugin binaries to a local directory. It satisfies
+// config.Installer so it can be passed directly to (*config.Config).Run.
+type PluginInstaller struct {
+	downloader Downloader
+	verifier   verify.Verifier
+	baseDir    string
+	cfg        *config.Config
+}
+
+// NewPluginInstaller returns a PluginInstaller that installs binaries under baseDir, recording resolved
+// digests and lifecycle transitions on cfg as it goes.
+func NewPluginInstaller(downloader Downloader, verifier verify.Verifier, baseDir string, cfg *config.Config) *PluginInstaller {
+	return &PluginInstaller{downloader: downloader, verifier: verifier, baseDir: baseDir, cfg: cfg}
+}
+
+// Install downloads item, verifies it against item.Sha256/item.Digest (or the digest the transport
+// itself resolved, e.g. an OCI digest) when one is available, and atomically installs it to disk.
+func (p *PluginInstaller) Install(item types.PluginItem) error {
+	if item.Disabled {
+		return nil
+	}
+
+	rc, resolvedDigest, err := p.downloader.Fetch(context.Background(), item)
+	if err != nil {
+		return fmt.Errorf("failed to fetch plugin %s: %w", item.Name, err)
+	}
+	defer rc.Close()
+
+	namespace, name, err := config.GetPluginDirectoryAndFilename(item.Name)
+	if err != nil {
+		return err
+	}
+	destDir := filepath.Join(p.baseDir, namespace)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create plugin directory %s: %w", destDir, err)
+	}
+	destPath := filepath.Join(destDir, name)
+
+	// item.Digest pins the OCI *manifest* for oci:// (and bare registry-ref) locations, which is not
+	// what the downloaded bytes hash to, so it cannot be used to verify the blob directly there. The
+	// puller already verified the manifest's own content-address and resolved resolvedDigest to the
+	// layer's real digest, so that's what we check the stream against instead.
+	expectedDigest := item.Sha256
+	if expectedDigest == "" && !isOCILocation(item) {
+		expectedDigest = item.Digest
+	}
+	if expectedDigest == "" {
+		expectedDigest = resolvedDigest
+	}
+
+	if err := InstallVerified(p.verifier, rc, destPath, expectedDigest); err != nil {
+		return err
+	}
+
+	installedDigest := expectedDigest
+	if installedDigest == "" {
+		installedDigest = resolvedDigest
+	}
+	p.cfg.SetPluginDigest(item.Name, item.Type, installedDigest)
+	return nil
+}
+
+// isOCILocation reports whether item is resolved through the OCI puller, either via an explicit oci://
+// prefix or the bare "<registry>/<repository>" + Digest convention config.ValidateConfig documents.
+func isOCILocation(item types.PluginItem) bool {
+	return strings.HasPrefix(item.Location, "oci://") || (item.Digest != "" && !strings.Contains(item.Location, "://"))
+}
+
+// Remove deletes the on-disk binary for item. A missing file is not an error, the plugin may never
+// have been successfully installed in the first place.
+func (p *PluginInstaller) Remove(item types.PluginItem) error {
+	namespace, name, err := config.GetPluginDirectoryAndFilename(item.Name)
+	if err != nil {
+		return err
+	}
+	destPath := filepath.Join(p.baseDir, namespace, name)
+	if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove plugin binary %s: %w", destPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Package oci resolves content-addressed plugin references against an OCI distribution-spec registry,
+// e.g. ghcr.io/org/plugin@sha256:<hex>.
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Ref is a parsed OCI reference of the form <registry>/<repository>@sha256:<hex>. The digest names the
+// image manifest, not the blob: a manifest has its own distinct layer digest(s) for the content it
+// describes.
+type Ref struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+var refRe = regexp.MustCompile(`^([a-zA-Z0-9.\-]+(?::[0-9]+)?)/(.+)@((?i:sha256):[a-fA-F0-9]{64})$`)
+
+// ParseRef parses a digest-pinned OCI reference. The tag form (without @sha256:...) is intentionally
+// not supported here, digest pinning is required for content-addressable installs.
+func ParseRef(ref string) (Ref, error) {
+	matches := refRe.FindStringSubmatch(ref)
+	if matches == nil {
+		return Ref{}, fmt.Errorf("oci reference %q must be in the form <registry>/<repository>@sha256:<hex>", ref)
+	}
+	return Ref{
+		Registry:   matches[1],
+		Repository: matches[2],
+		Digest:     strings.ToLower(matches[3]),
+	}, nil
+}
+
+// manifestAcceptHeader lists the manifest media types a single-layer plugin artifact is expected to use.
+const manifestAcceptHeader = "application/vnd.oci.image.manifest.v1+json,application/vnd.docker.distribution.manifest.v2+json"
+
+// manifest is the minimal subset of an OCI/Docker image manifest needed to find a plugin binary's blob.
+type manifest struct {
+	Layers []struct {
+		Digest string `json:"digest"`
+		Size   int64  `json:"size"`
+	} `json:"layers"`
+}
+
+// Puller fetches plugin binaries from an OCI distribution-spec registry by resolving a digest-pinned
+// manifest reference down to the single blob it describes.
+type Puller struct {
+	httpClient *http.Client
+}
+
+// NewPuller returns a Puller using the given http.Client, or http.DefaultClient if nil.
+func NewPuller(httpClient *http.Client) *Puller {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Puller{httpClient: httpClient}
+}
+
+// PullBlob resolves ref's manifest (verifying it against ref.Digest, the content-addressed identifier
+// the operator pinned) and returns a reader over the single layer it describes, along with that layer's
+// own digest, which is what the downloaded bytes actually hash to and what callers should verify the
+// stream against.
+func (p *Puller) PullBlob(ref Ref) (io.ReadCloser, string, error) {
+	m, err := p.fetchManifest(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(m.Layers) != 1 {
+		return nil, "", fmt.Errorf("oci artifact %s must have exactly one layer (the plugin binary), found %d", ref.Digest, len(m.Layers))
+	}
+	layerDigest := m.Layers[0].Digest
+
+	blobURL := fmt.Sprintf("https://%s/v2/%s/blobs/%s", ref.Registry, ref.Repository, layerDigest)
+	resp, err := p.getAuthenticated(blobURL, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch oci blob %s: %w", layerDigest, err)
+	}
+	return resp.Body, layerDigest, nil
+}
+
+// fetchManifest downloads ref's manifest and verifies its raw bytes hash to ref.Digest before trusting
+// anything it declares, registries are not otherwise required to have validated what they serve back
+// for a digest-addressed request.
+func (p *Puller) fetchManifest(ref Ref) (*manifest, error) {
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.Registry, ref.Repository, ref.Digest)
+	resp, err := p.getAuthenticated(manifestURL, manifestAcceptHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch oci manifest %s: %w", ref.Digest, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read oci manifest %s: %w", ref.Digest, err)
+	}
+
+	sum := sha256.Sum256(body)
+	gotDigest := "sha256:" + hex.EncodeToString(sum[:])
+	if gotDigest != ref.Digest {
+		return nil, fmt.Errorf("oci manifest digest mismatch: expected %s but fetched content hashes to %s", ref.Digest, gotDigest)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse oci manifest %s: %w", ref.Digest, err)
+	}
+	return &m, nil
+}
+
+// getAuthenticated issues a GET against url, setting accept if non-empty, and transparently retries once
+// with a bearer token when the registry challenges the anonymous request with a 401 (the standard flow
+// ghcr.io, Docker Hub, and most distribution-spec registries use for anonymous pulls).
+func (p *Puller) getAuthenticated(url, accept string) (*http.Response, error) {
+	resp, err := p.doGet(url, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("Www-Authenticate")
+		resp.Body.Close()
+
+		token, err := p.fetchBearerToken(challenge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate against registry: %w", err)
+		}
+		resp, err = p.doGet(url, accept, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s for %s", resp.Status, url)
+	}
+	return resp, nil
+}
+
+func (p *Puller) doGet(rawURL, accept, bearerToken string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+	return p.httpClient.Do(req)
+}
+
+// fetchBearerToken implements the registry token auth flow (distribution-spec token authentication
+// specification): parse the realm/service/scope out of a Www-Authenticate: Bearer challenge, request a
+// token from the realm for an anonymous pull, and return it.
+func (p *Puller) fetchBearerToken(challenge string) (string, error) {
+	realm, service, scope, err := parseBearerChallenge(challenge)
+	if err != nil {
+		return "", err
+	}
+
+	tokenURL := realm
+	query := url.Values{}
+	if service != "" {
+		query.Set("service", service)
+	}
+	if scope != "" {
+		query.Set("scope", scope)
+	}
+	if encoded := query.Encode(); encoded != "" {
+		tokenURL += "?" + encoded
+	}
+
+	resp, err := p.httpClient.Get(tokenURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach token endpoint %s: %w", realm, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint %s returned unexpected status %s", realm, resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response from %s: %w", realm, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("token endpoint %s returned no token", realm)
+}
+
+// parseBearerChallenge parses a `Bearer realm="...",service="...",scope="..."` Www-Authenticate header.
+func parseBearerChallenge(header string) (realm, service, scope string, err error) {
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", "", "", fmt.Errorf("unsupported auth challenge %q", header)
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(header, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		val := strings.Trim(kv[1], `"`)
+		switch kv[0] {
+		case "realm":
+			realm = val
+		case "service":
+			service = val
+		case "scope":
+			scope = val
+		}
+	}
+	if realm == "" {
+		return "", "", "", fmt.Errorf("auth challenge %q missing realm", header)
+	}
+	return realm, service, scope, nil
+}
@@ -0,0 +1,242 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRef(t *testing.T) {
+	validDigest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    Ref
+		wantErr string
+	}{
+		{
+			name: "valid ref",
+			ref:  "ghcr.io/org/plugin@" + validDigest,
+			want: Ref{Registry: "ghcr.io", Repository: "org/plugin", Digest: validDigest},
+		},
+		{
+			name: "valid ref with port and nested repository",
+			ref:  "registry.example.com:5000/org/team/plugin@" + validDigest,
+			want: Ref{Registry: "registry.example.com:5000", Repository: "org/team/plugin", Digest: validDigest},
+		},
+		{
+			name: "digest is lowercased",
+			ref:  "ghcr.io/org/plugin@SHA256:" + strings.Repeat("A", 64),
+			want: Ref{Registry: "ghcr.io", Repository: "org/plugin", Digest: validDigest},
+		},
+		{
+			name:    "missing digest",
+			ref:     "ghcr.io/org/plugin",
+			wantErr: "must be in the form",
+		},
+		{
+			name:    "short digest",
+			ref:     "ghcr.io/org/plugin@sha256:abc",
+			wantErr: "must be in the form",
+		},
+		{
+			name:    "tag instead of digest",
+			ref:     "ghcr.io/org/plugin:v1",
+			wantErr: "must be in the form",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRef(tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBearerChallenge(t *testing.T) {
+	tests := []struct {
+		name                              string
+		header                            string
+		wantRealm, wantService, wantScope string
+		wantErr                           string
+	}{
+		{
+			name:        "full challenge",
+			header:      `Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:org/plugin:pull"`,
+			wantRealm:   "https://ghcr.io/token",
+			wantService: "ghcr.io",
+			wantScope:   "repository:org/plugin:pull",
+		},
+		{
+			name:      "realm only",
+			header:    `Bearer realm="https://example.com/token"`,
+			wantRealm: "https://example.com/token",
+		},
+		{
+			name:    "not a bearer challenge",
+			header:  `Basic realm="foo"`,
+			wantErr: "unsupported auth challenge",
+		},
+		{
+			name:    "missing realm",
+			header:  `Bearer service="ghcr.io"`,
+			wantErr: "missing realm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			realm, service, scope, err := parseBearerChallenge(tt.header)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if realm != tt.wantRealm || service != tt.wantService || scope != tt.wantScope {
+				t.Fatalf("got realm=%q service=%q scope=%q, want realm=%q service=%q scope=%q",
+					realm, service, scope, tt.wantRealm, tt.wantService, tt.wantScope)
+			}
+		})
+	}
+}
+
+// insecureClient returns an http.Client that trusts the given httptest TLS server's certificate, since
+// fetchManifest/PullBlob always dial https://.
+func insecureClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+}
+
+func refFor(t *testing.T, server *httptest.Server, repository, digest string) Ref {
+	t.Helper()
+	registry := strings.TrimPrefix(server.URL, "https://")
+	return Ref{Registry: registry, Repository: repository, Digest: digest}
+}
+
+func digestOf(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestPuller_PullBlob_VerifiesManifestDigest(t *testing.T) {
+	const blobContent = "plugin-binary"
+	layerDigest := digestOf([]byte(blobContent))
+	manifestBody := fmt.Appendf(nil, `{"layers":[{"digest":%q,"size":%d}]}`, layerDigest, len(blobContent))
+	manifestDigest := digestOf(manifestBody)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/org/plugin/manifests/"+manifestDigest, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(manifestBody)
+	})
+	mux.HandleFunc("/v2/org/plugin/blobs/"+layerDigest, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, blobContent)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	puller := NewPuller(insecureClient())
+	ref := refFor(t, server, "org/plugin", manifestDigest)
+
+	rc, gotDigest, err := puller.PullBlob(ref)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer rc.Close()
+	if gotDigest != layerDigest {
+		t.Fatalf("expected the layer's own digest %q to be returned, got %q", layerDigest, gotDigest)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read blob: %v", err)
+	}
+	if string(got) != blobContent {
+		t.Fatalf("expected blob content %q, got %q", blobContent, got)
+	}
+}
+
+func TestPuller_PullBlob_RejectsManifestDigestMismatch(t *testing.T) {
+	manifestBody := []byte(`{"layers":[{"digest":"sha256:` + strings.Repeat("a", 64) + `","size":1}]}`)
+
+	mux := http.NewServeMux()
+	wrongDigest := "sha256:" + strings.Repeat("b", 64)
+	mux.HandleFunc("/v2/org/plugin/manifests/"+wrongDigest, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(manifestBody)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	puller := NewPuller(insecureClient())
+	ref := refFor(t, server, "org/plugin", wrongDigest)
+
+	_, _, err := puller.PullBlob(ref)
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Fatalf("expected a digest mismatch error, got: %v", err)
+	}
+}
+
+func TestPuller_PullBlob_RetriesWithBearerTokenOn401(t *testing.T) {
+	const blobContent = "plugin-binary"
+	layerDigest := digestOf([]byte(blobContent))
+	manifestBody := fmt.Appendf(nil, `{"layers":[{"digest":%q,"size":%d}]}`, layerDigest, len(blobContent))
+	manifestDigest := digestOf(manifestBody)
+
+	var tokenURL string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"token":"test-token"}`)
+	})
+	mux.HandleFunc("/v2/org/plugin/manifests/"+manifestDigest, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm=%q,service="test"`, tokenURL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write(manifestBody)
+	})
+	mux.HandleFunc("/v2/org/plugin/blobs/"+layerDigest, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm=%q,service="test"`, tokenURL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, blobContent)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	tokenURL = server.URL + "/token"
+
+	puller := NewPuller(insecureClient())
+	ref := refFor(t, server, "org/plugin", manifestDigest)
+
+	rc, _, err := puller.PullBlob(ref)
+	if err != nil {
+		t.Fatalf("expected the puller to transparently retry with a bearer token, got: %v", err)
+	}
+	defer rc.Close()
+	got, _ := io.ReadAll(rc)
+	if string(got) != blobContent {
+		t.Fatalf("expected blob content %q, got %q", blobContent, got)
+	}
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PluginType represents the plugin section of the configmap that a PluginItem was declared in.
+type PluginType string
+
+const (
+	PluginTypeTrafficRouter  PluginType = "TrafficRouter"
+	PluginTypeMetricProvider PluginType = "MetricProvider"
+	PluginTypeStep           PluginType = "StepPlugin"
+)
+
+// PluginItem is the configuration for a single plugin as declared in the argo-rollouts-config configmap.
+type PluginItem struct {
+	// Name is the name of the plugin, in the format <namespace>/<name>, e.g. argoproj-labs/sample-plugin
+	Name string `json:"name"`
+	// Alias is an optional short local name (e.g. myrouter) that Rollouts/AnalysisTemplates may reference
+	// instead of the canonical <namespace>/<name> form. It must be unique per plugin type.
+	Alias string `json:"alias,omitempty"`
+	// Location is the URL (or OCI reference) the plugin binary should be downloaded from
+	Location string `json:"location"`
+	// Sha256 is the expected SHA-256 checksum (hex encoded) of the downloaded plugin binary. When set, the
+	// downloaded binary is verified against it before being installed and mismatches are treated as fatal errors.
+	Sha256 string `json:"sha256,omitempty"`
+	// Digest is the content-addressable identifier of the plugin artifact, e.g. sha256:<hex>. When Location
+	// is an oci:// reference or a bare registry/repository reference (e.g. ghcr.io/org/plugin), the plugin
+	// is pulled from that registry by this digest; for other locations the downloaded binary is verified
+	// against it when Sha256 is not set.
+	Digest string `json:"digest,omitempty"`
+	// Disabled allows an operator to keep a plugin entry in the configmap without it being downloaded/used.
+	Disabled bool `json:"disabled,omitempty"`
+	// DownloadTimeout bounds the whole download of this plugin, retries included, e.g. "30s". Defaults to
+	// the Downloader's own default when unset. OCI pulls and file:// reads do not observe it.
+	DownloadTimeout metav1.Duration `json:"downloadTimeout,omitempty"`
+	// Type is populated by the config package based on which configmap section the item was declared in. It is
+	// not part of the YAML schema.
+	Type PluginType `json:"-"`
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestPluginItem_DownloadTimeoutUnmarshalsHumanReadableDuration(t *testing.T) {
+	const doc = `
+name: argoproj-labs/sample-plugin
+location: https://example.com/plugin
+downloadTimeout: 30s
+`
+	var item PluginItem
+	if err := yaml.Unmarshal([]byte(doc), &item); err != nil {
+		t.Fatalf("failed to unmarshal plugin item: %v", err)
+	}
+	if item.DownloadTimeout.Duration != 30*time.Second {
+		t.Fatalf("expected a 30s download timeout, got: %s", item.DownloadTimeout.Duration)
+	}
+}
+
+func TestPluginItem_DownloadTimeoutDefaultsToZero(t *testing.T) {
+	const doc = `
+name: argoproj-labs/sample-plugin
+location: https://example.com/plugin
+`
+	var item PluginItem
+	if err := yaml.Unmarshal([]byte(doc), &item); err != nil {
+		t.Fatalf("failed to unmarshal plugin item: %v", err)
+	}
+	if item.DownloadTimeout.Duration != 0 {
+		t.Fatalf("expected a zero download timeout when unset, got: %s", item.DownloadTimeout.Duration)
+	}
+}
@@ -0,0 +1,65 @@
+// Package verify provides content verification for downloaded plugin binaries.
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Verifier verifies that the bytes read from r match an expected digest, without buffering the whole
+// stream in memory.
+type Verifier interface {
+	// Verify streams r, computing its digest, and returns an error if it does not match expected.
+	// expected may be a bare hex-encoded sha256 sum or a prefixed digest such as "sha256:<hex>".
+	Verify(r io.Reader, expected string) error
+}
+
+// Sha256Verifier is a Verifier that checks the SHA-256 checksum of a stream.
+type Sha256Verifier struct{}
+
+// NewSha256Verifier returns a Verifier that validates streams against a SHA-256 digest.
+func NewSha256Verifier() *Sha256Verifier {
+	return &Sha256Verifier{}
+}
+
+func (v *Sha256Verifier) Verify(r io.Reader, expected string) error {
+	wantHex, err := normalizeDigest(expected)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return fmt.Errorf("failed to read stream while verifying digest: %w", err)
+	}
+	gotHex := hex.EncodeToString(h.Sum(nil))
+
+	if gotHex != wantHex {
+		return fmt.Errorf("digest mismatch: expected sha256:%s but got sha256:%s", wantHex, gotHex)
+	}
+	return nil
+}
+
+// normalizeDigest accepts either a bare hex sha256 sum or a "sha256:<hex>" digest and returns the
+// lowercased hex portion.
+func normalizeDigest(digest string) (string, error) {
+	hexPart := digest
+	if strings.Contains(digest, ":") {
+		parts := strings.SplitN(digest, ":", 2)
+		if parts[0] != "sha256" {
+			return "", fmt.Errorf("unsupported digest algorithm %q, only sha256 is supported", parts[0])
+		}
+		hexPart = parts[1]
+	}
+	hexPart = strings.ToLower(hexPart)
+	if len(hexPart) != 64 {
+		return "", fmt.Errorf("invalid sha256 digest %q: expected 64 hex characters", digest)
+	}
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return "", fmt.Errorf("invalid sha256 digest %q: %w", digest, err)
+	}
+	return hexPart, nil
+}
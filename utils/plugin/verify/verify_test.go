@@ -0,0 +1,45 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSha256Verifier_Verify(t *testing.T) {
+	const content = "plugin-binary-contents"
+	// sha256("plugin-binary-contents")
+	const wantHex = "8ce2e044c3603daa748080cc8b86b34c6228e56bc6a2d2c192745fee28bb63cb"
+
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  string
+	}{
+		{name: "bare hex digest matches", expected: wantHex},
+		{name: "prefixed digest matches", expected: "sha256:" + wantHex},
+		{name: "uppercase digest matches", expected: strings.ToUpper(wantHex)},
+		{name: "mismatched digest", expected: strings.Repeat("a", 64), wantErr: "digest mismatch"},
+		{name: "wrong algorithm prefix", expected: "sha1:" + wantHex, wantErr: "unsupported digest algorithm"},
+		{name: "wrong length", expected: "abcd", wantErr: "expected 64 hex characters"},
+		{name: "not hex", expected: strings.Repeat("z", 64), wantErr: "invalid sha256 digest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewSha256Verifier()
+			err := v.Verify(strings.NewReader(content), tt.expected)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}